@@ -0,0 +1,130 @@
+// Copyright 2017, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tag
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// The wire format is a single version byte followed by zero or more
+// fields. Each field starts with a one byte field ID, followed by a
+// varint-encoded key length, the key bytes, a varint-encoded value length
+// and the value bytes. Decoders that encounter a field ID they don't
+// recognize skip it using that same shape, so new field types can be
+// introduced later without breaking older decoders.
+const (
+	wireFormatVersion = byte(0)
+	fieldIDTag        = byte(0)
+)
+
+// errMalformed is returned by Decode and DecodeEach when the input is not
+// a well-formed tags wire format.
+var errMalformed = errors.New("tag: malformed wire format")
+
+// Encode encodes m into the binary wire format used to propagate tag maps
+// across process boundaries. A nil m encodes to a valid, empty map. Tags
+// with TTLNoPropagation metadata are local to the process and are
+// dropped from the encoded form.
+func Encode(m *Map) []byte {
+	b := []byte{wireFormatVersion}
+	if m == nil {
+		return b
+	}
+	for k, v := range m.m {
+		if v.md.ttl == TTLNoPropagation {
+			continue
+		}
+		b = append(b, fieldIDTag)
+		b = encodeString(b, k.name)
+		b = encodeString(b, v.value)
+	}
+	return b
+}
+
+func encodeString(b []byte, s string) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], uint64(len(s)))
+	b = append(b, buf[:n]...)
+	return append(b, s...)
+}
+
+// Decode decodes b, as produced by Encode, into a *Map.
+func Decode(b []byte) (*Map, error) {
+	m := newMap(0)
+	if err := DecodeEach(b, func(k Key, v string) error {
+		// Decoded tags have already crossed the wire once, so they carry
+		// unlimited propagation from here on.
+		m.insert(k, v, metadata{ttl: TTLUnlimitedPropagation})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DecodeEach decodes b, as produced by Encode, calling fn for every tag it
+// contains. Callers that only need to iterate over the entries, such as
+// exporters or gRPC interceptors, can use it to avoid allocating a *Map.
+func DecodeEach(b []byte, fn func(k Key, v string) error) error {
+	if len(b) == 0 || b[0] != wireFormatVersion {
+		return errMalformed
+	}
+	b = b[1:]
+	for len(b) > 0 {
+		fieldID := b[0]
+		b = b[1:]
+
+		key, rest, err := decodeString(b)
+		if err != nil {
+			return err
+		}
+		value, rest, err := decodeString(rest)
+		if err != nil {
+			return err
+		}
+		b = rest
+
+		if fieldID != fieldIDTag {
+			// Unknown field: its bytes have already been consumed above,
+			// so simply move on to the next one.
+			continue
+		}
+		if !checkValue(value) {
+			return fmt.Errorf("tag: invalid value %q for key %q: %w", value, key, ErrInvalidValue)
+		}
+		k, err := NewKey(key)
+		if err != nil {
+			return err
+		}
+		if err := fn(k, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeString reads a varint-encoded length followed by that many bytes
+// from b, returning the decoded string and the unconsumed remainder.
+func decodeString(b []byte) (s string, rest []byte, err error) {
+	n, c := binary.Uvarint(b)
+	if c <= 0 || n > uint64(len(b)-c) {
+		return "", nil, errMalformed
+	}
+	b = b[c:]
+	return string(b[:n]), b[n:], nil
+}
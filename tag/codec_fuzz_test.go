@@ -0,0 +1,50 @@
+// Copyright 2017, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tag
+
+import (
+	"context"
+	"testing"
+)
+
+func FuzzDecode(f *testing.F) {
+	k, err := NewKey("k")
+	if err != nil {
+		f.Fatalf("NewKey() = %v", err)
+	}
+	m, err := NewMap(context.Background(), Insert(k, "v1"))
+	if err != nil {
+		f.Fatalf("NewMap() = %v", err)
+	}
+
+	f.Add([]byte{wireFormatVersion})
+	f.Add(Encode(m))
+	f.Add([]byte{})
+	f.Add([]byte{1})
+	f.Add([]byte{wireFormatVersion, fieldIDTag})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		decoded, err := Decode(data)
+		if err != nil {
+			return
+		}
+		// Anything Decode accepted must also survive an encode/decode
+		// round trip without error.
+		if _, err := Decode(Encode(decoded)); err != nil {
+			t.Fatalf("Decode(Encode(%v)) = %v, want nil error", decoded, err)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+// Copyright 2017, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tag
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	k1 := mustNewKey(t, "k1")
+	k2 := mustNewKey(t, "k2")
+
+	m, err := NewMap(context.Background(), Insert(k1, "v1"), Insert(k2, "v2"))
+	if err != nil {
+		t.Fatalf("NewMap() = %v", err)
+	}
+
+	decoded, err := Decode(Encode(m))
+	if err != nil {
+		t.Fatalf("Decode() = %v", err)
+	}
+	if !reflect.DeepEqual(m.m, decoded.m) {
+		t.Errorf("Decode(Encode(m)) = %v, want %v", decoded.m, m.m)
+	}
+}
+
+func TestEncodeEmptyMap(t *testing.T) {
+	decoded, err := Decode(Encode(nil))
+	if err != nil {
+		t.Fatalf("Decode() = %v", err)
+	}
+	if len(decoded.m) != 0 {
+		t.Errorf("Decode(Encode(nil)) = %v, want empty map", decoded.m)
+	}
+}
+
+func TestDecodeEach(t *testing.T) {
+	k1 := mustNewKey(t, "k1")
+	m, err := NewMap(context.Background(), Insert(k1, "v1"))
+	if err != nil {
+		t.Fatalf("NewMap() = %v", err)
+	}
+
+	var got []Tag
+	err = DecodeEach(Encode(m), func(k Key, v string) error {
+		got = append(got, Tag{Key: k, Value: v})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DecodeEach() = %v", err)
+	}
+	want := []Tag{{Key: k1, Value: "v1"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DecodeEach() = %v, want %v", got, want)
+	}
+}
+
+func TestDecodeMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []byte
+	}{
+		{"empty input", []byte{}},
+		{"bad version", []byte{1}},
+		{"truncated field", []byte{wireFormatVersion, fieldIDTag}},
+		{"truncated value", []byte{wireFormatVersion, fieldIDTag, 2, 'k', '1'}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decode(tt.in); err == nil {
+				t.Error("Decode() = nil error, want error")
+			}
+		})
+	}
+}
+
+func TestDecodeSkipsUnknownField(t *testing.T) {
+	b := []byte{wireFormatVersion}
+	b = append(b, 0xFF) // unrecognized field ID
+	b = encodeString(b, "unknown-key")
+	b = encodeString(b, "unknown-value")
+	b = append(b, fieldIDTag)
+	b = encodeString(b, "k1")
+	b = encodeString(b, "v1")
+
+	m, err := Decode(b)
+	if err != nil {
+		t.Fatalf("Decode() = %v", err)
+	}
+	if v, ok := m.Value(mustNewKey(t, "k1")); !ok || v != "v1" {
+		t.Errorf("m.Value(k1) = %q, %v, want v1, true", v, ok)
+	}
+	if len(m.m) != 1 {
+		t.Errorf("len(m.m) = %d, want 1", len(m.m))
+	}
+}
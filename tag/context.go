@@ -0,0 +1,33 @@
+// Copyright 2017, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tag
+
+import "context"
+
+type ctxKey struct{}
+
+// FromContext returns the tag map stored in ctx, or nil if ctx carries no
+// tag map.
+func FromContext(ctx context.Context) *Map {
+	m, _ := ctx.Value(ctxKey{}).(*Map)
+	return m
+}
+
+// NewContext returns a copy of ctx that carries m, replacing any tag map
+// ctx may already carry.
+func NewContext(ctx context.Context, m *Map) context.Context {
+	return context.WithValue(ctx, ctxKey{}, m)
+}
@@ -0,0 +1,41 @@
+// Copyright 2017, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tag
+
+import "fmt"
+
+// Key represents a key for a value stored in a tag.Map.
+type Key struct {
+	name string
+}
+
+// Name returns the name of the key.
+func (k Key) Name() string {
+	return k.name
+}
+
+// NewKey creates a string key identified by name. Two keys created with
+// the same name are equal, since Key is comparable by value. NewKey
+// returns an error if name doesn't meet the requirements documented on
+// ErrInvalidKeyName. This is the only place key names are validated: the
+// mutators do not check keys, so the zero Key, whose name is empty, is
+// accepted by them.
+func NewKey(name string) (Key, error) {
+	if !checkKeyName(name) {
+		return Key{}, fmt.Errorf("tag: invalid key name %q: %w", name, ErrInvalidKeyName)
+	}
+	return Key{name: name}, nil
+}
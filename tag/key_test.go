@@ -0,0 +1,64 @@
+// Copyright 2017, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tag
+
+import (
+	"strings"
+	"testing"
+)
+
+// mustNewKey is a test helper that creates a key known to be valid,
+// failing the test immediately otherwise.
+func mustNewKey(t *testing.T, name string) Key {
+	t.Helper()
+	k, err := NewKey(name)
+	if err != nil {
+		t.Fatalf("NewKey(%q) = %v", name, err)
+	}
+	return k
+}
+
+func TestNewKeyValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{"empty", "", true},
+		{"max length", strings.Repeat("a", MaxKeyLength), false},
+		{"too long", strings.Repeat("a", MaxKeyLength+1), true},
+		{"control character", "k\x01ey", true},
+		{"high bit byte", "k\x80ey", true},
+		{"equals sign", "k=ey", true},
+		{"simple", "key", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKey(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewKey(%q) error = %v, wantErr %v", tt.key, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewKeyEquality(t *testing.T) {
+	k1 := mustNewKey(t, "same")
+	k2 := mustNewKey(t, "same")
+	if k1 != k2 {
+		t.Errorf("NewKey(%q) = %v, want %v", "same", k2, k1)
+	}
+}
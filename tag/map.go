@@ -30,14 +30,31 @@ type Tag struct {
 
 // Map is a map of tags. Use NewMap to build tag maps.
 type Map struct {
-	m map[Key]string
+	m map[Key]valueWithMetadata
+}
+
+// valueWithMetadata pairs a tag's value with the propagation metadata it
+// was inserted with.
+type valueWithMetadata struct {
+	value string
+	md    metadata
 }
 
 // Value returns the value for the key if a value
 // for the key exists.
 func (m *Map) Value(k Key) (string, bool) {
 	v, ok := m.m[k]
-	return v, ok
+	return v.value, ok
+}
+
+// ValueMetadata returns the TTL associated with the value for the key if
+// a value for the key exists.
+func (m *Map) ValueMetadata(k Key) (TTL, bool) {
+	v, ok := m.m[k]
+	if !ok {
+		return TTL{}, false
+	}
+	return v.md.ttl, true
 }
 
 func (m *Map) String() string {
@@ -50,27 +67,27 @@ func (m *Map) String() string {
 	var buffer bytes.Buffer
 	buffer.WriteString("{ ")
 	for _, k := range keys {
-		buffer.WriteString(fmt.Sprintf("{%v %v}", k.name, m.m[k]))
+		buffer.WriteString(fmt.Sprintf("{%v %v}", k.name, m.m[k].value))
 	}
 	buffer.WriteString(" }")
 	return buffer.String()
 }
 
-func (m *Map) insert(k Key, v string) {
+func (m *Map) insert(k Key, v string, md metadata) {
 	if _, ok := m.m[k]; ok {
 		return
 	}
-	m.m[k] = v
+	m.m[k] = valueWithMetadata{value: v, md: md}
 }
 
-func (m *Map) update(k Key, v string) {
+func (m *Map) update(k Key, v string, md metadata) {
 	if _, ok := m.m[k]; ok {
-		m.m[k] = v
+		m.m[k] = valueWithMetadata{value: v, md: md}
 	}
 }
 
-func (m *Map) upsert(k Key, v string) {
-	m.m[k] = v
+func (m *Map) upsert(k Key, v string, md metadata) {
+	m.m[k] = valueWithMetadata{value: v, md: md}
 }
 
 func (m *Map) delete(k Key) {
@@ -78,7 +95,7 @@ func (m *Map) delete(k Key) {
 }
 
 func newMap(sizeHint int) *Map {
-	return &Map{m: make(map[Key]string, sizeHint)}
+	return &Map{m: make(map[Key]valueWithMetadata, sizeHint)}
 }
 
 // Mutator modifies a tag map.
@@ -89,13 +106,22 @@ type Mutator interface {
 // Insert returns a mutator that inserts a
 // value associated with k. If k already exists in the tag map,
 // mutator doesn't update the value.
+//
+// Insert is sugar for InsertWithMetadata with TTLUnlimitedPropagation.
 func Insert(k Key, v string) Mutator {
+	return InsertWithMetadata(k, v, TTLUnlimitedPropagation)
+}
+
+// InsertWithMetadata returns a mutator that inserts a value associated
+// with k and annotated with ttl. If k already exists in the tag map, the
+// mutator doesn't update the value.
+func InsertWithMetadata(k Key, v string, ttl TTL) Mutator {
 	return &mutator{
 		fn: func(m *Map) (*Map, error) {
 			if !checkValue(v) {
-				return nil, errInvalid
+				return nil, fmt.Errorf("tag: invalid value %q for key %q: %w", v, k.Name(), ErrInvalidValue)
 			}
-			m.insert(k, v)
+			m.insert(k, v, metadata{ttl: ttl})
 			return m, nil
 		},
 	}
@@ -104,13 +130,22 @@ func Insert(k Key, v string) Mutator {
 // Update returns a mutator that updates the
 // value of the tag associated with k with v. If k doesn't
 // exists in the tag map, the mutator doesn't insert the value.
+//
+// Update is sugar for UpdateWithMetadata with TTLUnlimitedPropagation.
 func Update(k Key, v string) Mutator {
+	return UpdateWithMetadata(k, v, TTLUnlimitedPropagation)
+}
+
+// UpdateWithMetadata returns a mutator that updates the value of the tag
+// associated with k with v, annotated with ttl. If k doesn't exist in the
+// tag map, the mutator doesn't insert the value.
+func UpdateWithMetadata(k Key, v string, ttl TTL) Mutator {
 	return &mutator{
 		fn: func(m *Map) (*Map, error) {
 			if !checkValue(v) {
-				return nil, errInvalid
+				return nil, fmt.Errorf("tag: invalid value %q for key %q: %w", v, k.Name(), ErrInvalidValue)
 			}
-			m.update(k, v)
+			m.update(k, v, metadata{ttl: ttl})
 			return m, nil
 		},
 	}
@@ -120,13 +155,22 @@ func Update(k Key, v string) Mutator {
 // value of the tag associated with k with v. It inserts the
 // value if k doesn't exist already. It mutates the value
 // if k already exists.
+//
+// Upsert is sugar for UpsertWithMetadata with TTLUnlimitedPropagation.
 func Upsert(k Key, v string) Mutator {
+	return UpsertWithMetadata(k, v, TTLUnlimitedPropagation)
+}
+
+// UpsertWithMetadata returns a mutator that upserts the value of the tag
+// associated with k with v, annotated with ttl. It inserts the value if k
+// doesn't exist already. It mutates the value if k already exists.
+func UpsertWithMetadata(k Key, v string, ttl TTL) Mutator {
 	return &mutator{
 		fn: func(m *Map) (*Map, error) {
 			if !checkValue(v) {
-				return nil, errInvalid
+				return nil, fmt.Errorf("tag: invalid value %q for key %q: %w", v, k.Name(), ErrInvalidValue)
 			}
-			m.upsert(k, v)
+			m.upsert(k, v, metadata{ttl: ttl})
 			return m, nil
 		},
 	}
@@ -146,12 +190,11 @@ func Delete(k Key) Mutator {
 // NewMap returns a new tag map originated from the incoming context
 // and modified with the provided mutators.
 func NewMap(ctx context.Context, mutator ...Mutator) (*Map, error) {
-	// TODO(jbd): Implement validation of keys and values.
 	m := newMap(0)
 	orig := FromContext(ctx)
 	if orig != nil {
 		for k, v := range orig.m {
-			m.insert(k, v)
+			m.insert(k, v.value, v.md)
 		}
 	}
 	var err error
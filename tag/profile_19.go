@@ -0,0 +1,43 @@
+// Copyright 2017, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//go:build go1.9
+// +build go1.9
+
+package tag
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// Do calls f with a context derived from ctx, attaching any tags found in
+// ctx's tag map to that context and to the goroutine as runtime/pprof
+// labels for the duration of the call. When ctx carries no tag map, f is
+// called with ctx itself. This
+// lets CPU and heap profiles gathered while f runs be broken down by the
+// same dimensions used for stats, such as method or customer_id.
+func Do(ctx context.Context, f func(ctx context.Context)) {
+	m := FromContext(ctx)
+	if m == nil {
+		f(ctx)
+		return
+	}
+	labels := make([]string, 0, 2*len(m.m))
+	for k, v := range m.m {
+		labels = append(labels, k.name, v.value)
+	}
+	pprof.Do(ctx, pprof.Labels(labels...), f)
+}
@@ -0,0 +1,64 @@
+// Copyright 2017, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//go:build go1.9
+// +build go1.9
+
+package tag
+
+import (
+	"context"
+	"runtime/pprof"
+	"testing"
+)
+
+func TestDoAttachesLabels(t *testing.T) {
+	k := mustNewKey(t, "k")
+	m, err := NewMap(context.Background(), Insert(k, "v1"))
+	if err != nil {
+		t.Fatalf("NewMap() = %v", err)
+	}
+	ctx := NewContext(context.Background(), m)
+
+	Do(ctx, func(ctx context.Context) {
+		if v, ok := pprof.Label(ctx, "k"); !ok || v != "v1" {
+			t.Errorf("pprof.Label(ctx, %q) = %q, %v, want v1, true", "k", v, ok)
+		}
+	})
+}
+
+func TestDoDoesNotLeakTagsInsertedInsideF(t *testing.T) {
+	outer := mustNewKey(t, "outer")
+	inner := mustNewKey(t, "inner")
+
+	m, err := NewMap(context.Background(), Insert(outer, "v1"))
+	if err != nil {
+		t.Fatalf("NewMap() = %v", err)
+	}
+	ctx := NewContext(context.Background(), m)
+
+	Do(ctx, func(ctx context.Context) {
+		if _, err := NewMap(ctx, Insert(inner, "v2")); err != nil {
+			t.Fatalf("NewMap() = %v", err)
+		}
+
+		if v, ok := pprof.Label(ctx, "inner"); ok {
+			t.Errorf("pprof.Label(ctx, %q) = %q, true, want not found", "inner", v)
+		}
+		if v, ok := pprof.Label(ctx, "outer"); !ok || v != "v1" {
+			t.Errorf("pprof.Label(ctx, %q) = %q, %v, want v1, true", "outer", v, ok)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+// Copyright 2017, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//go:build !go1.9
+// +build !go1.9
+
+package tag
+
+import "context"
+
+// Do calls f with ctx. runtime/pprof labels were introduced in Go 1.9, so
+// on older releases this is a plain passthrough.
+func Do(ctx context.Context, f func(ctx context.Context)) {
+	f(ctx)
+}
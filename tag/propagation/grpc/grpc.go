@@ -0,0 +1,97 @@
+// Copyright 2017, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package grpc provides gRPC client and server interceptors that
+// propagate a tag map across an RPC using a grpc-tags-bin binary
+// metadata entry.
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/mpetazzoni/opencensus-go/tag"
+)
+
+// metadataKey is the gRPC metadata key used to carry the encoded tag map.
+// The "-bin" suffix tells gRPC to transmit the value as raw, unencoded
+// bytes instead of ASCII.
+const metadataKey = "grpc-tags-bin"
+
+// UnaryClientInterceptor attaches the tag map carried by ctx, if any, to
+// the outgoing unary RPC.
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+}
+
+// StreamClientInterceptor attaches the tag map carried by ctx, if any, to
+// the outgoing streaming RPC.
+func StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+}
+
+// UnaryServerInterceptor populates the context passed to handler with the
+// tag map carried by the incoming unary RPC's metadata, if any.
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(incomingContext(ctx), req)
+}
+
+// StreamServerInterceptor populates the context of the stream passed to
+// handler with the tag map carried by the incoming streaming RPC's
+// metadata, if any.
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &taggedServerStream{ServerStream: ss, ctx: incomingContext(ss.Context())})
+}
+
+// outgoingContext returns ctx with the encoded tag map, if any, attached
+// as outgoing grpc-tags-bin metadata. Tags carrying TTLNoPropagation
+// metadata are dropped, as tag.Encode already does.
+func outgoingContext(ctx context.Context) context.Context {
+	m := tag.FromContext(ctx)
+	if m == nil {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, metadataKey, string(tag.Encode(m)))
+}
+
+// incomingContext returns ctx with the tag map decoded from the incoming
+// grpc-tags-bin metadata, if any and well-formed, attached. A missing or
+// malformed entry leaves ctx untouched rather than failing the RPC.
+func incomingContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	vals := md.Get(metadataKey)
+	if len(vals) == 0 {
+		return ctx
+	}
+	m, err := tag.Decode([]byte(vals[0]))
+	if err != nil {
+		return ctx
+	}
+	return tag.NewContext(ctx, m)
+}
+
+type taggedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *taggedServerStream) Context() context.Context {
+	return s.ctx
+}
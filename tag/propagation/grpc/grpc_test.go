@@ -0,0 +1,215 @@
+// Copyright 2017, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/mpetazzoni/opencensus-go/tag"
+)
+
+// rawCodec marshals/unmarshals []byte payloads as-is, so unary and
+// streaming round trips can be tested here without generated protobuf
+// stubs.
+type rawCodec struct{}
+
+func (rawCodec) Marshal(v interface{}) ([]byte, error) {
+	switch b := v.(type) {
+	case []byte:
+		return b, nil
+	case *[]byte:
+		return *b, nil
+	default:
+		return nil, fmt.Errorf("rawCodec: unsupported type %T", v)
+	}
+}
+
+func (rawCodec) Unmarshal(data []byte, v interface{}) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("rawCodec: unsupported type %T", v)
+	}
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+func (rawCodec) Name() string { return "raw" }
+
+func init() {
+	encoding.RegisterCodec(rawCodec{})
+}
+
+// unaryServiceDesc registers a single unary method by hand, without
+// generated protobuf stubs, so the call is dispatched through gRPC's
+// normal unary RPC path (and hence through UnaryServerInterceptor),
+// unlike grpc.UnknownServiceHandler.
+var unaryServiceDesc = grpc.ServiceDesc{
+	ServiceName: "propagation.Test",
+	HandlerType: (*interface{})(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Unary",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				var req []byte
+				if err := dec(&req); err != nil {
+					return nil, err
+				}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(func(context.Context) ([]byte, error))(ctx)
+				}
+				if interceptor == nil {
+					return handler(ctx, req)
+				}
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/propagation.Test/Unary"}
+				return interceptor(ctx, req, info, handler)
+			},
+		},
+	},
+}
+
+// TestInterceptorsPropagateTags dials an in-process gRPC server over
+// bufconn and checks that a tag map attached to the client context is
+// observable, via the server interceptor, on the server side.
+func TestInterceptorsPropagateTags(t *testing.T) {
+	k, err := tag.NewKey("k")
+	if err != nil {
+		t.Fatalf("NewKey() = %v", err)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	defer lis.Close()
+
+	gotValue := make(chan string, 1)
+	srv := grpc.NewServer(
+		grpc.StreamInterceptor(StreamServerInterceptor),
+		grpc.UnknownServiceHandler(func(srv interface{}, stream grpc.ServerStream) error {
+			m := tag.FromContext(stream.Context())
+			if m == nil {
+				gotValue <- ""
+				return nil
+			}
+			v, _ := m.Value(k)
+			gotValue <- v
+			return nil
+		}),
+	)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithStreamInterceptor(StreamClientInterceptor),
+	)
+	if err != nil {
+		t.Fatalf("Dial() = %v", err)
+	}
+	defer conn.Close()
+
+	m, err := tag.NewMap(context.Background(), tag.Insert(k, "v1"))
+	if err != nil {
+		t.Fatalf("NewMap() = %v", err)
+	}
+	ctx := tag.NewContext(context.Background(), m)
+
+	desc := &grpc.StreamDesc{StreamName: "Method", ClientStreams: true, ServerStreams: true}
+	stream, err := conn.NewStream(ctx, desc, "/propagation.Test/Method")
+	if err != nil {
+		t.Fatalf("NewStream() = %v", err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("CloseSend() = %v", err)
+	}
+
+	select {
+	case got := <-gotValue:
+		if got != "v1" {
+			t.Errorf("server observed tag value %q, want v1", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the server to observe the propagated tag")
+	}
+}
+
+// TestUnaryInterceptorsPropagateTags dials an in-process gRPC server over
+// bufconn and checks that a tag map attached to the client context of a
+// unary call is observable, via the server interceptor, on the server
+// side.
+func TestUnaryInterceptorsPropagateTags(t *testing.T) {
+	k, err := tag.NewKey("k")
+	if err != nil {
+		t.Fatalf("NewKey() = %v", err)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	defer lis.Close()
+
+	srv := grpc.NewServer(grpc.UnaryInterceptor(UnaryServerInterceptor))
+	srv.RegisterService(&unaryServiceDesc, func(ctx context.Context) ([]byte, error) {
+		m := tag.FromContext(ctx)
+		if m == nil {
+			return nil, nil
+		}
+		v, _ := m.Value(k)
+		return []byte(v), nil
+	})
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor),
+	)
+	if err != nil {
+		t.Fatalf("Dial() = %v", err)
+	}
+	defer conn.Close()
+
+	m, err := tag.NewMap(context.Background(), tag.Insert(k, "v1"))
+	if err != nil {
+		t.Fatalf("NewMap() = %v", err)
+	}
+	ctx := tag.NewContext(context.Background(), m)
+
+	req := []byte("req")
+	var reply []byte
+	if err := conn.Invoke(ctx, "/propagation.Test/Unary", &req, &reply, grpc.ForceCodec(rawCodec{})); err != nil {
+		t.Fatalf("Invoke() = %v", err)
+	}
+	if got := string(reply); got != "v1" {
+		t.Errorf("server observed tag value %q, want v1", got)
+	}
+}
+
+func TestIncomingContextWithoutMetadataIsUnchanged(t *testing.T) {
+	ctx := context.Background()
+	if got := incomingContext(ctx); got != ctx {
+		t.Error("incomingContext() modified a context carrying no metadata")
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2017, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package http propagates tag maps across HTTP requests using a single,
+// base64url-encoded header.
+package http
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/mpetazzoni/opencensus-go/tag"
+)
+
+// defaultHeader is the header used to carry the encoded tag map when
+// HTTPFormat.Header is empty.
+const defaultHeader = "Tags-Bin"
+
+// HTTPFormat implements tag map propagation across HTTP requests by
+// encoding the tag map with tag.Encode and storing the result,
+// base64url-encoded, in a single header.
+type HTTPFormat struct {
+	// Header is the name of the HTTP header carrying the encoded tag map.
+	// Header defaults to "Tags-Bin" when empty.
+	Header string
+}
+
+func (f *HTTPFormat) header() string {
+	if f.Header != "" {
+		return f.Header
+	}
+	return defaultHeader
+}
+
+// FromRequest extracts a tag map from req. A request carrying no header
+// yields an empty map and a nil error. A request carrying a header that
+// cannot be decoded yields an empty map and a non-nil error, rather than
+// panicking.
+func (f *HTTPFormat) FromRequest(req *http.Request) (*tag.Map, error) {
+	empty, _ := tag.NewMap(context.Background())
+
+	h := req.Header.Get(f.header())
+	if h == "" {
+		return empty, nil
+	}
+
+	b, err := base64.URLEncoding.DecodeString(h)
+	if err != nil {
+		return empty, fmt.Errorf("propagation/http: decoding %s header: %v", f.header(), err)
+	}
+	m, err := tag.Decode(b)
+	if err != nil {
+		return empty, fmt.Errorf("propagation/http: decoding tag map: %v", err)
+	}
+	return m, nil
+}
+
+// ToRequest encodes m and sets it on req's header, overwriting any value
+// already present. Tags carrying TTLNoPropagation metadata are dropped,
+// as tag.Encode already does.
+func (f *HTTPFormat) ToRequest(m *tag.Map, req *http.Request) {
+	req.Header.Set(f.header(), base64.URLEncoding.EncodeToString(tag.Encode(m)))
+}
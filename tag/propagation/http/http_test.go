@@ -0,0 +1,104 @@
+// Copyright 2017, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package http
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mpetazzoni/opencensus-go/tag"
+)
+
+func TestRoundTrip(t *testing.T) {
+	k, err := tag.NewKey("k")
+	if err != nil {
+		t.Fatalf("NewKey() = %v", err)
+	}
+	m, err := tag.NewMap(context.Background(), tag.Insert(k, "v1"))
+	if err != nil {
+		t.Fatalf("NewMap() = %v", err)
+	}
+
+	var format HTTPFormat
+	req := httptest.NewRequest("GET", "/", nil)
+	format.ToRequest(m, req)
+
+	got, err := format.FromRequest(req)
+	if err != nil {
+		t.Fatalf("FromRequest() = %v", err)
+	}
+	if v, ok := got.Value(k); !ok || v != "v1" {
+		t.Errorf("got.Value(k) = %q, %v, want v1, true", v, ok)
+	}
+}
+
+func TestFromRequestNoHeader(t *testing.T) {
+	var format HTTPFormat
+	req := httptest.NewRequest("GET", "/", nil)
+
+	m, err := format.FromRequest(req)
+	if err != nil {
+		t.Fatalf("FromRequest() = %v", err)
+	}
+	if m == nil {
+		t.Fatal("FromRequest() returned a nil map")
+	}
+}
+
+func TestFromRequestGarbageHeader(t *testing.T) {
+	var format HTTPFormat
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(format.header(), "not valid base64url!!")
+
+	m, err := format.FromRequest(req)
+	if err == nil {
+		t.Fatal("FromRequest() with a garbage header: got nil error, want error")
+	}
+	if m == nil {
+		t.Fatal("FromRequest() with a garbage header returned a nil map")
+	}
+}
+
+func TestCustomHeader(t *testing.T) {
+	k, err := tag.NewKey("k")
+	if err != nil {
+		t.Fatalf("NewKey() = %v", err)
+	}
+	m, err := tag.NewMap(context.Background(), tag.Insert(k, "v1"))
+	if err != nil {
+		t.Fatalf("NewMap() = %v", err)
+	}
+
+	format := HTTPFormat{Header: "X-Custom-Tags-Bin"}
+	req := httptest.NewRequest("GET", "/", nil)
+	format.ToRequest(m, req)
+
+	if req.Header.Get(defaultHeader) != "" {
+		t.Error("request carries the default header even though a custom one was configured")
+	}
+	if req.Header.Get("X-Custom-Tags-Bin") == "" {
+		t.Error("request doesn't carry the configured custom header")
+	}
+
+	got, err := format.FromRequest(req)
+	if err != nil {
+		t.Fatalf("FromRequest() = %v", err)
+	}
+	if v, ok := got.Value(k); !ok || v != "v1" {
+		t.Errorf("got.Value(k) = %q, %v, want v1, true", v, ok)
+	}
+}
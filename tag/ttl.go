@@ -0,0 +1,43 @@
+// Copyright 2017, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tag
+
+// TTL is metadata that determines how many hops a tag can propagate
+// across before it is dropped.
+type TTL struct {
+	ttl int
+}
+
+const (
+	ttlUnlimitedPropagation = iota
+	ttlNoPropagation
+)
+
+// TTLUnlimitedPropagation marks a tag as eligible to propagate across any
+// number of process boundaries. This is the TTL used by Insert, Update
+// and Upsert.
+var TTLUnlimitedPropagation = TTL{ttl: ttlUnlimitedPropagation}
+
+// TTLNoPropagation marks a tag as local to the current process: it is
+// visible on the context but is stripped when the tag map is encoded onto
+// the wire.
+var TTLNoPropagation = TTL{ttl: ttlNoPropagation}
+
+// metadata holds the propagation information carried alongside a tag's
+// value inside a Map.
+type metadata struct {
+	ttl TTL
+}
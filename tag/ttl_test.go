@@ -0,0 +1,65 @@
+// Copyright 2017, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tag
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTTLNoPropagationDroppedOnEncode(t *testing.T) {
+	kLocal := mustNewKey(t, "local")
+	kWire := mustNewKey(t, "wire")
+
+	m, err := NewMap(context.Background(),
+		InsertWithMetadata(kLocal, "v1", TTLNoPropagation),
+		InsertWithMetadata(kWire, "v2", TTLUnlimitedPropagation))
+	if err != nil {
+		t.Fatalf("NewMap() = %v", err)
+	}
+
+	if ttl, ok := m.ValueMetadata(kLocal); !ok || ttl != TTLNoPropagation {
+		t.Errorf("ValueMetadata(kLocal) = %v, %v, want TTLNoPropagation, true", ttl, ok)
+	}
+
+	decoded, err := Decode(Encode(m))
+	if err != nil {
+		t.Fatalf("Decode() = %v", err)
+	}
+	if _, ok := decoded.Value(kLocal); ok {
+		t.Error("decoded map retained a TTLNoPropagation tag")
+	}
+	if v, ok := decoded.Value(kWire); !ok || v != "v2" {
+		t.Errorf("decoded.Value(kWire) = %q, %v, want v2, true", v, ok)
+	}
+}
+
+func TestNewMapPreservesMetadata(t *testing.T) {
+	k := mustNewKey(t, "k")
+	orig, err := NewMap(context.Background(), InsertWithMetadata(k, "v1", TTLNoPropagation))
+	if err != nil {
+		t.Fatalf("NewMap() = %v", err)
+	}
+	ctx := NewContext(context.Background(), orig)
+
+	next, err := NewMap(ctx)
+	if err != nil {
+		t.Fatalf("NewMap() = %v", err)
+	}
+	if ttl, ok := next.ValueMetadata(k); !ok || ttl != TTLNoPropagation {
+		t.Errorf("ValueMetadata(k) = %v, %v, want TTLNoPropagation, true", ttl, ok)
+	}
+}
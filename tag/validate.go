@@ -0,0 +1,66 @@
+// Copyright 2017, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tag
+
+import "errors"
+
+const (
+	// MaxKeyLength is the maximum length, in bytes, a key name is allowed
+	// to have.
+	MaxKeyLength = 255
+
+	// MaxValueLength is the maximum length, in bytes, a tag value is
+	// allowed to have.
+	MaxValueLength = 255
+)
+
+// ErrInvalidKeyName is returned when a key name doesn't meet the
+// requirements below. A key name must be non-empty, no longer than
+// MaxKeyLength, and composed of printable ASCII characters (0x20-0x7E)
+// other than '='.
+var ErrInvalidKeyName = errors.New("tag: invalid key name")
+
+// ErrInvalidValue is returned when a tag value doesn't meet the
+// requirements below. A value must be non-empty, no longer than
+// MaxValueLength, and composed of printable ASCII characters (0x20-0x7E)
+// other than '='.
+var ErrInvalidValue = errors.New("tag: invalid value")
+
+// checkKeyName reports whether name is a legal key name.
+func checkKeyName(name string) bool {
+	return checkPrintable(name, MaxKeyLength)
+}
+
+// checkValue reports whether v is a legal tag value.
+func checkValue(v string) bool {
+	return checkPrintable(v, MaxValueLength)
+}
+
+// checkPrintable reports whether s is non-empty, no longer than maxLen,
+// and made up exclusively of printable ASCII characters other than '=',
+// which is reserved so the wire format and HTTP-header propagation of
+// tags remain unambiguous.
+func checkPrintable(s string, maxLen int) bool {
+	if len(s) == 0 || len(s) > maxLen {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < ' ' || s[i] > '~' || s[i] == '=' {
+			return false
+		}
+	}
+	return true
+}
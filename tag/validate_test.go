@@ -0,0 +1,51 @@
+// Copyright 2017, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tag
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  bool
+	}{
+		{"empty", "", false},
+		{"max length", strings.Repeat("v", MaxValueLength), true},
+		{"too long", strings.Repeat("v", MaxValueLength+1), false},
+		{"control character", "v\x01alue", false},
+		{"high bit byte", "v\x80alue", false},
+		{"equals sign", "v=alue", false},
+		{"simple", "value", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkValue(tt.value); got != tt.want {
+				t.Errorf("checkValue(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInsertReportsInvalidValue(t *testing.T) {
+	k := mustNewKey(t, "k")
+	if _, err := Insert(k, "").Mutate(newMap(0)); err == nil {
+		t.Error("Insert with empty value: got nil error, want error")
+	}
+}